@@ -0,0 +1,61 @@
+// Package beacon provides an unbiasable randomness feed for stake slot
+// selection, so ProposeSlot/AcceptSlot ordering and tie-breaks can't be
+// gamed by validators choosing when to submit.
+package beacon
+
+import (
+	"context"
+	"errors"
+)
+
+// BeaconEntry is a single verifiable randomness round.
+type BeaconEntry struct {
+	Round      uint64 `json:"round"`
+	Randomness []byte `json:"randomness"`
+	Signature  []byte `json:"signature"`
+}
+
+// BeaconAPI is a source of verifiable randomness rounds.
+type BeaconAPI interface {
+	// Entry fetches (and verifies) the entry for round. Implementations may
+	// block briefly while retrying against a slow beacon node.
+	Entry(ctx context.Context, round uint64) (BeaconEntry, error)
+
+	// VerifyEntry checks that curr is a valid successor to prev.
+	VerifyEntry(prev, curr BeaconEntry) error
+
+	// LatestBeaconRound returns the highest round the implementation has
+	// observed and verified so far.
+	LatestBeaconRound() uint64
+}
+
+// BeaconNetwork pins a BeaconAPI to the block height range it is active
+// for, so the beacon source can be rotated across chain upgrades without
+// losing the ability to verify history.
+type BeaconNetwork struct {
+	// FromHeight is the first block height this network is active at.
+	FromHeight uint64
+	API        BeaconAPI
+}
+
+// BeaconNetworks is an ascending-by-FromHeight list of beacon networks.
+type BeaconNetworks []BeaconNetwork
+
+// ErrNoActiveNetwork is returned when no network is configured for height.
+var ErrNoActiveNetwork = errors.New("beacon: no network configured for height")
+
+// Active returns the network in effect at height: the last one in the list
+// whose FromHeight is <= height.
+func (ns BeaconNetworks) Active(height uint64) (BeaconAPI, error) {
+	var active *BeaconAPI
+	for i := range ns {
+		if ns[i].FromHeight > height {
+			break
+		}
+		active = &ns[i].API
+	}
+	if active == nil {
+		return nil, ErrNoActiveNetwork
+	}
+	return *active, nil
+}
@@ -0,0 +1,72 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+)
+
+// defaultCacheSize bounds how many verified rounds are kept in memory.
+const defaultCacheSize = 256
+
+// defaultRetryBudget is how many fetch attempts in all CachedBeacon makes
+// for a round before giving up on it.
+const defaultRetryBudget = 3
+
+// CachedBeacon wraps a BeaconAPI with a small LRU of previously verified
+// entries and a bounded, lazy retry budget, so a slow or briefly
+// unreachable beacon node doesn't stall every caller asking for the same
+// round.
+type CachedBeacon struct {
+	inner       BeaconAPI
+	cache       *lru.Cache
+	retryBudget int
+	retryDelay  time.Duration
+}
+
+// NewCachedBeacon wraps inner with an LRU cache of size entries.
+func NewCachedBeacon(inner BeaconAPI, size int) *CachedBeacon {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+	cache, _ := lru.New(size)
+	return &CachedBeacon{
+		inner:       inner,
+		cache:       cache,
+		retryBudget: defaultRetryBudget,
+		retryDelay:  200 * time.Millisecond,
+	}
+}
+
+func (c *CachedBeacon) Entry(ctx context.Context, round uint64) (BeaconEntry, error) {
+	if cached, ok := c.cache.Get(round); ok {
+		return cached.(BeaconEntry), nil
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < c.retryBudget; attempt++ {
+		entry, err := c.inner.Entry(ctx, round)
+		if err == nil {
+			c.cache.Add(round, entry)
+			return entry, nil
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return BeaconEntry{}, ctx.Err()
+		case <-time.After(c.retryDelay):
+		}
+	}
+	return BeaconEntry{}, fmt.Errorf("beacon: round %d unavailable after %d attempts: %v", round, c.retryBudget, lastErr)
+}
+
+func (c *CachedBeacon) VerifyEntry(prev, curr BeaconEntry) error {
+	return c.inner.VerifyEntry(prev, curr)
+}
+
+func (c *CachedBeacon) LatestBeaconRound() uint64 {
+	return c.inner.LatestBeaconRound()
+}
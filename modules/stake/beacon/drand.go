@@ -0,0 +1,109 @@
+package beacon
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// VerifyFunc checks a drand round signature against the network's pinned
+// threshold-BLS group public key. It is a hook rather than a hard
+// dependency so DrandBeacon doesn't force a BLS library choice on callers
+// that only need MockBeacon for tests.
+type VerifyFunc func(groupPubKey, previousSignature, signature []byte, round uint64) error
+
+// DrandBeacon fetches randomness rounds from a drand HTTP relay and
+// verifies each one against a pinned group public key before trusting it.
+type DrandBeacon struct {
+	endpoint    string
+	groupPubKey []byte
+	verify      VerifyFunc
+	client      *http.Client
+
+	mu     sync.Mutex
+	latest uint64
+}
+
+// NewDrandBeacon creates a client for the drand HTTP relay at endpoint,
+// verifying rounds against groupPubKey using verify.
+func NewDrandBeacon(endpoint string, groupPubKey []byte, verify VerifyFunc) *DrandBeacon {
+	return &DrandBeacon{
+		endpoint:    endpoint,
+		groupPubKey: groupPubKey,
+		verify:      verify,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type drandRoundResponse struct {
+	Round             uint64 `json:"round"`
+	Randomness        string `json:"randomness"`
+	Signature         string `json:"signature"`
+	PreviousSignature string `json:"previous_signature"`
+}
+
+func (b *DrandBeacon) Entry(ctx context.Context, round uint64) (BeaconEntry, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/public/%d", b.endpoint, round), nil)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+	resp, err := b.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return BeaconEntry{}, fmt.Errorf("beacon: drand returned status %d for round %d", resp.StatusCode, round)
+	}
+
+	var body drandRoundResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return BeaconEntry{}, err
+	}
+
+	randomness, err := hex.DecodeString(body.Randomness)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+	signature, err := hex.DecodeString(body.Signature)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+	prevSignature, err := hex.DecodeString(body.PreviousSignature)
+	if err != nil {
+		return BeaconEntry{}, err
+	}
+
+	if b.verify != nil {
+		if err := b.verify(b.groupPubKey, prevSignature, signature, body.Round); err != nil {
+			return BeaconEntry{}, fmt.Errorf("beacon: signature verification failed for round %d: %v", body.Round, err)
+		}
+	}
+
+	b.mu.Lock()
+	if body.Round > b.latest {
+		b.latest = body.Round
+	}
+	b.mu.Unlock()
+
+	return BeaconEntry{Round: body.Round, Randomness: randomness, Signature: signature}, nil
+}
+
+func (b *DrandBeacon) VerifyEntry(prev, curr BeaconEntry) error {
+	if b.verify == nil {
+		return errors.New("beacon: no VerifyFunc configured for this network")
+	}
+	return b.verify(b.groupPubKey, prev.Signature, curr.Signature, curr.Round)
+}
+
+func (b *DrandBeacon) LatestBeaconRound() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.latest
+}
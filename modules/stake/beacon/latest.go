@@ -0,0 +1,30 @@
+package beacon
+
+import "sync"
+
+// latestMu guards latestVerified.
+var (
+	latestMu       sync.Mutex
+	latestVerified BeaconEntry
+)
+
+// SetLatestVerified records entry as the latest beacon round verified for
+// the current height. It is called once per committed block -- today by
+// rpc/namespaces/cmt's watchBeacon, which polls Tendermint NewBlock events
+// since the stake keeper's BeginBlock isn't part of this checkout; once
+// that keeper exists its BeginBlock should call this directly instead, so
+// slot-selection code (e.g. GetCandidateKey ordering) can read a stable
+// value for the whole block without re-fetching from the active network.
+func SetLatestVerified(entry BeaconEntry) {
+	latestMu.Lock()
+	defer latestMu.Unlock()
+	latestVerified = entry
+}
+
+// LatestVerified returns the beacon entry SetLatestVerified last recorded,
+// the zero BeaconEntry if no block has been processed yet.
+func LatestVerified() BeaconEntry {
+	latestMu.Lock()
+	defer latestMu.Unlock()
+	return latestVerified
+}
@@ -0,0 +1,39 @@
+package ethereum
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/eth"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+)
+
+// LocalClient returns the in-process Tendermint RPC client used to query and
+// broadcast against the local node.
+func (b *Backend) LocalClient() rpcclient.Client {
+	return b.localClient
+}
+
+// ChainID returns the Tendermint chain id, or an empty string if tendermint
+// has not finished starting up yet.
+func (b *Backend) ChainID() string {
+	return b.chainID
+}
+
+// AccountManager returns the underlying go-ethereum account manager, shared
+// with the embedded eth service.
+func (b *Backend) AccountManager() *accounts.Manager {
+	return b.ethereum.AccountManager()
+}
+
+// Ethereum returns the embedded go-ethereum service so namespace packages
+// can delegate to its own standard eth/web3 API implementations.
+func (b *Backend) Ethereum() *eth.Ethereum {
+	return b.ethereum
+}
+
+// EthChainID returns the configured EVM chain id, used to sign stake txs
+// with the same replay protection as regular eth txs.
+func (b *Backend) EthChainID() *big.Int {
+	return b.ethConfig.ChainId
+}
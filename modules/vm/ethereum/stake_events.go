@@ -0,0 +1,80 @@
+package ethereum
+
+import (
+	"fmt"
+	"strconv"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+)
+
+// StakeEventType identifies which stake keeper tx produced a StakeTxResult,
+// using the "type" tag values DecodeStakeResult reads from DeliverTx.Tags.
+type StakeEventType string
+
+const (
+	StakeEventDeclare      StakeEventType = "stake.declare"
+	StakeEventProposeSlot  StakeEventType = "stake.propose_slot"
+	StakeEventAcceptSlot   StakeEventType = "stake.accept_slot"
+	StakeEventWithdrawSlot StakeEventType = "stake.withdraw_slot"
+	StakeEventCancelSlot   StakeEventType = "stake.cancel_slot"
+)
+
+// Tag keys DecodeStakeResult reads from a stake tx's DeliverTx.Tags, kept as
+// constants so DecodeStakeResult can't drift on the wire format
+// independently of whatever (currently absent from this checkout) keeper
+// code eventually emits them.
+const (
+	tagType      = "type"
+	tagCandidate = "candidate"
+	tagPubKey    = "pubkey"
+	tagAmount    = "amount"
+	tagSlotId    = "slot_id"
+)
+
+// StakeTxResult is the decoded, dashboard-friendly view of a stake tx's
+// DeliverTx.Tags, alongside the raw broadcast result so callers can still
+// inspect hashes/height/gas without re-querying state to learn what the tx
+// actually did.
+type StakeTxResult struct {
+	Raw *ctypes.ResultBroadcastTxCommit `json:"raw"`
+
+	Type      StakeEventType `json:"type,omitempty"`
+	Candidate string         `json:"candidate,omitempty"`
+	PubKey    string         `json:"pubKey,omitempty"`
+	Amount    int64          `json:"amount,omitempty"`
+	SlotId    string         `json:"slotId,omitempty"`
+}
+
+// DecodeStakeResult extracts the structured stake.* event tags a stake
+// keeper tx attaches to its DeliverTx response, starting from fallback --
+// the caller's own view of what it just asked the tx to do, built from the
+// request args it already had on hand before broadcasting. The stake
+// keeper (modules/stake's tx handlers, absent from this checkout) doesn't
+// attach these tags yet, so without a fallback a broadcast result would
+// decode into a StakeTxResult with every field but Raw empty; every
+// DeliverTx.Tags entry that is present still overrides the fallback, so
+// this stays correct once the keeper is wired to emit them.
+func DecodeStakeResult(result *ctypes.ResultBroadcastTxCommit, fallback StakeTxResult) (*StakeTxResult, error) {
+	r := fallback
+	r.Raw = result
+	for _, tag := range result.DeliverTx.Tags {
+		key, value := string(tag.Key), string(tag.Value)
+		switch key {
+		case tagType:
+			r.Type = StakeEventType(value)
+		case tagCandidate:
+			r.Candidate = value
+		case tagPubKey:
+			r.PubKey = value
+		case tagAmount:
+			amount, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("stake event: invalid %q tag %q: %v", tagAmount, value, err)
+			}
+			r.Amount = amount
+		case tagSlotId:
+			r.SlotId = value
+		}
+	}
+	return &r, nil
+}
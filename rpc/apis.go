@@ -0,0 +1,73 @@
+// Package rpc assembles the RPC API surface travis exposes to clients,
+// mirroring go-ethereum's namespace-based `--rpcapi` gating.
+package rpc
+
+import (
+	"strings"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/viper"
+
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+	"github.com/CyberMiles/travis/rpc/namespaces/cmt"
+	"github.com/CyberMiles/travis/rpc/namespaces/eth"
+	"github.com/CyberMiles/travis/rpc/namespaces/net"
+	"github.com/CyberMiles/travis/rpc/namespaces/personal"
+	"github.com/CyberMiles/travis/rpc/namespaces/stake"
+	"github.com/CyberMiles/travis/rpc/namespaces/web3"
+	travisCommands "github.com/CyberMiles/travis/server/commands"
+)
+
+// defaultNamespaces is what RegisterAPIs enables when neither
+// enabledNamespaces nor --rpcapi name any, matching go-ethereum's own
+// default RPC namespace set.
+var defaultNamespaces = []string{"eth", "net", "web3", "personal"}
+
+// EnabledNamespaces parses the --rpcapi flag (a comma separated namespace
+// list) into the slice RegisterAPIs expects, falling back to
+// defaultNamespaces when the flag is unset.
+func EnabledNamespaces() []string {
+	raw := viper.GetString(travisCommands.FlagRPCApi)
+	if raw == "" {
+		return defaultNamespaces
+	}
+
+	var namespaces []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			namespaces = append(namespaces, name)
+		}
+	}
+	if len(namespaces) == 0 {
+		return defaultNamespaces
+	}
+	return namespaces
+}
+
+// RegisterAPIs builds the RPC API set for backend, restricted to the
+// namespaces named in enabledNamespaces (e.g. []string{"eth", "net",
+// "personal", "stake", "cmt"}). Unknown namespace names are ignored. A nil
+// or empty enabledNamespaces falls back to EnabledNamespaces(), so the
+// --rpcapi flag still takes effect for callers that don't parse it
+// themselves.
+func RegisterAPIs(backend *ethereum.Backend, networkVersion uint64, enabledNamespaces []string) []gethrpc.API {
+	if len(enabledNamespaces) == 0 {
+		enabledNamespaces = EnabledNamespaces()
+	}
+
+	available := map[string][]gethrpc.API{
+		"eth":      eth.APIs(backend),
+		"net":      net.APIs(networkVersion),
+		"personal": personal.APIs(backend),
+		"web3":     web3.APIs(),
+		"cmt":      cmt.APIs(backend),
+		"stake":    stake.APIs(backend),
+	}
+
+	var apis []gethrpc.API
+	for _, name := range enabledNamespaces {
+		apis = append(apis, available[name]...)
+	}
+	return apis
+}
@@ -0,0 +1,102 @@
+package cmt
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cast"
+
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	cmn "github.com/tendermint/tmlibs/common"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/CyberMiles/travis/modules/stake/beacon"
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+)
+
+// beaconNetworks lists the beacon sources active across travis' history,
+// keyed by the block height each one takes over at. It defaults to a
+// deterministic MockBeacon until a real drand group is pinned via
+// genesis/upgrade configuration.
+var beaconNetworks = beacon.BeaconNetworks{
+	{FromHeight: 0, API: beacon.NewCachedBeacon(beacon.NewMockBeacon([]byte("travis-stake-beacon")), 0)},
+}
+
+// PublicCmtAPI offers cmt related RPC methods.
+// #unstable
+type PublicCmtAPI struct {
+	backend *ethereum.Backend
+}
+
+// NewPublicCmtAPI creates a new cmt API instance.
+// #unstable
+func NewPublicCmtAPI(backend *ethereum.Backend) *PublicCmtAPI {
+	watchBeacon(backend)
+	return &PublicCmtAPI{
+		backend: backend,
+	}
+}
+
+func (s *PublicCmtAPI) GetBlock(height uint64) (*ctypes.ResultBlock, error) {
+	h := cast.ToInt64(height)
+	return s.backend.LocalClient().Block(&h)
+}
+
+func (s *PublicCmtAPI) GetTransaction(hash string) (*ctypes.ResultTx, error) {
+	bkey, err := hex.DecodeString(cmn.StripHex(hash))
+	if err != nil {
+		return nil, err
+	}
+	return s.backend.LocalClient().Tx(bkey, false)
+}
+
+func (s *PublicCmtAPI) GetTransactionFromBlock(height uint64, index int64) (*ctypes.ResultTx, error) {
+	h := cast.ToInt64(height)
+	block, err := s.backend.LocalClient().Block(&h)
+	if err != nil {
+		return nil, err
+	}
+	if index >= block.Block.NumTxs {
+		return nil, errors.New(fmt.Sprintf("No transaction in block %d, index %d. ", height, index))
+	}
+	hash := block.Block.Txs[index].Hash()
+	return s.GetTransaction(hex.EncodeToString(hash))
+}
+
+// GetBeaconEntry returns the verified randomness beacon entry for round,
+// sourced from whichever beacon network is active at the current height.
+// This is a read-only query for an arbitrary client-chosen round -- it does
+// not advance beacon.LatestVerified, which tracks per-block progress via
+// watchBeacon instead (see beacon_poller.go).
+func (s *PublicCmtAPI) GetBeaconEntry(round uint64) (*beacon.BeaconEntry, error) {
+	status, err := s.backend.LocalClient().Status()
+	if err != nil {
+		return nil, err
+	}
+
+	api, err := beaconNetworks.Active(uint64(status.LatestBlockHeight))
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := api.Entry(context.Background(), round)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// APIs returns the RPC descriptors for the cmt namespace.
+func APIs(backend *ethereum.Backend) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "cmt",
+			Version:   "1.0",
+			Service:   NewPublicCmtAPI(backend),
+			Public:    true,
+		},
+	}
+}
@@ -0,0 +1,75 @@
+package cmt
+
+import (
+	"context"
+
+	"github.com/CyberMiles/travis/modules/stake/beacon"
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+)
+
+// queryNewBlock is the Tendermint event query used to drive the beacon
+// poller off real chain progress, mirroring rpc/namespaces/eth/events.go's
+// use of the same query to bridge new blocks into the filter system.
+const queryNewBlock = "tm.event='NewBlock'"
+
+// beaconEventsClient is the subset of the Tendermint RPC client the beacon
+// poller needs, pulled out as an interface (mirroring eventsClient in the
+// eth namespace) so it can be exercised with a fake in tests.
+type beaconEventsClient interface {
+	Subscribe(query string, out chan<- interface{}) error
+	Unsubscribe(query string) error
+}
+
+// watchBeacon refreshes beacon.LatestVerified once per committed block,
+// rather than whenever a cmt_getBeaconEntry client happens to query a
+// round. It is started from NewPublicCmtAPI and runs for the life of the
+// process.
+//
+// This is the nearest equivalent this checkout can wire up to "call
+// SetLatestVerified from BeginBlock": the stake keeper (modules/stake,
+// absent from this checkout) isn't present to host a real BeginBlock hook,
+// but Tendermint's NewBlock event fires at the same cadence. The round
+// recorded each block is the active network's LatestBeaconRound, so it only
+// moves when that network has fetched a higher round: MockBeacon always
+// reports 0, and DrandBeacon's rises with any Entry call, including one made
+// by cmt_getBeaconEntry. Once the keeper package
+// exists, its BeginBlock should call beacon.SetLatestVerified directly
+// instead of going through this poller.
+func watchBeacon(backend *ethereum.Backend) {
+	ec, ok := backend.LocalClient().(beaconEventsClient)
+	if !ok {
+		return
+	}
+
+	blockCh := make(chan interface{}, 16)
+	if err := ec.Subscribe(queryNewBlock, blockCh); err != nil {
+		return
+	}
+
+	go func() {
+		for range blockCh {
+			advanceBeacon(backend)
+		}
+	}()
+}
+
+// advanceBeacon fetches the entry for the active beacon network's
+// LatestBeaconRound at the current height and records it, so GetCandidateKey-style slot
+// ordering can read a stable per-block value via beacon.LatestVerified.
+func advanceBeacon(backend *ethereum.Backend) {
+	status, err := backend.LocalClient().Status()
+	if err != nil {
+		return
+	}
+
+	api, err := beaconNetworks.Active(uint64(status.LatestBlockHeight))
+	if err != nil {
+		return
+	}
+
+	entry, err := api.Entry(context.Background(), api.LatestBeaconRound())
+	if err != nil {
+		return
+	}
+	beacon.SetLatestVerified(entry)
+}
@@ -0,0 +1,33 @@
+// Package eth exposes the standard go-ethereum eth namespace. Travis embeds
+// a full go-ethereum node for EVM execution, so rather than reimplement
+// eth_* methods we simply forward to the APIs it already registers. The one
+// exception is the filter/subscription family, served by PublicFilterAPI
+// from Tendermint events.
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+)
+
+// APIs returns the go-ethereum eth namespace RPC descriptors served by the
+// embedded go-ethereum node, filtered down to the eth namespace only (the
+// node also registers its own net/web3/personal/admin APIs, which travis
+// serves through its own namespace packages instead).
+func APIs(backend *ethereum.Backend) []rpc.API {
+	var apis []rpc.API
+	for _, api := range backend.Ethereum().APIs() {
+		if api.Namespace == "eth" {
+			apis = append(apis, api)
+		}
+	}
+
+	apis = append(apis, rpc.API{
+		Namespace: "eth",
+		Version:   "1.0",
+		Service:   NewPublicFilterAPI(backend),
+		Public:    true,
+	})
+	return apis
+}
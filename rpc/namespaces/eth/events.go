@@ -0,0 +1,190 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+)
+
+// tendermint event queries the EventSystem subscribes to on the local node.
+const (
+	queryNewBlock = "tm.event='NewBlock'"
+	queryTx       = "tm.event='Tx'"
+)
+
+// eventsClient is the subset of the Tendermint RPC client the EventSystem
+// needs to bridge block/tx events into our own filter registry. It is
+// satisfied by backend.LocalClient(), pulled out as an interface so the
+// EventSystem can be exercised with a fake in tests.
+type eventsClient interface {
+	Subscribe(query string, out chan<- interface{}) error
+	Unsubscribe(query string) error
+}
+
+// EventSystem bridges Tendermint's event bus into a set of subscribers
+// interested in new headers, EVM logs, and pending transaction hashes.
+type EventSystem struct {
+	backend *ethereum.Backend
+
+	install   chan *eventSub
+	uninstall chan *eventSub
+
+	headers chan *types.Header
+	logs    chan []*types.Log
+	txs     chan common.Hash
+}
+
+// eventSub is a single subscriber fed by the EventSystem's dispatch loop.
+type eventSub struct {
+	typ     filterType
+	crit    FilterCriteria
+	logs    chan []*types.Log
+	headers chan *types.Header
+	hashes  chan common.Hash
+}
+
+// NewEventSystem creates and starts an EventSystem bridging backend's local
+// Tendermint node into the filter package.
+func NewEventSystem(backend *ethereum.Backend) *EventSystem {
+	es := &EventSystem{
+		backend:   backend,
+		install:   make(chan *eventSub),
+		uninstall: make(chan *eventSub),
+		headers:   make(chan *types.Header, 128),
+		logs:      make(chan []*types.Log, 128),
+		txs:       make(chan common.Hash, 128),
+	}
+	go es.eventLoop()
+	return es
+}
+
+// subscribeTendermint wires up the raw Tendermint subscriptions, if the
+// local client supports them. It is a no-op (rather than an error) when it
+// doesn't, so the RPC methods degrade to serving only historical data via
+// GetLogs instead of taking the node down.
+func (es *EventSystem) subscribeTendermint() {
+	ec, ok := es.backend.LocalClient().(eventsClient)
+	if !ok {
+		return
+	}
+
+	blockCh := make(chan interface{}, 128)
+	if err := ec.Subscribe(queryNewBlock, blockCh); err == nil {
+		go func() {
+			for evt := range blockCh {
+				header, ok := decodeNewBlockEvent(evt)
+				if !ok {
+					continue
+				}
+				es.headers <- header
+			}
+		}()
+	}
+
+	txCh := make(chan interface{}, 128)
+	if err := ec.Subscribe(queryTx, txCh); err == nil {
+		go func() {
+			for evt := range txCh {
+				hash, logs, ok := decodeTxEvent(evt)
+				if !ok {
+					continue
+				}
+				es.txs <- hash
+				if len(logs) > 0 {
+					es.logs <- logs
+				}
+			}
+		}()
+	}
+}
+
+// decodeNewBlockEvent pulls the header out of a Tendermint NewBlock event
+// payload. It only fills in ParentHash, Root, Number and Time (Bloom and
+// the other EVM header fields stay empty); a full go-ethereum header
+// conversion belongs with
+// the rest of the block-to-EVM-header derivation in the vm module.
+func decodeNewBlockEvent(evt interface{}) (*types.Header, bool) {
+	data, ok := evt.(tmtypes.EventDataNewBlock)
+	if !ok || data.Block == nil {
+		return nil, false
+	}
+
+	h := data.Block.Header
+	return &types.Header{
+		ParentHash: common.BytesToHash(h.LastBlockID.Hash),
+		Root:       common.BytesToHash(h.AppHash),
+		Number:     big.NewInt(h.Height),
+		Time:       big.NewInt(h.Time.Unix()),
+	}, true
+}
+
+// decodeTxEvent pulls the tx hash and any EVM logs the vm module RLP-
+// encoded into DeliverTx.Data out of a Tendermint Tx event payload,
+// mirroring decodeTxLogs in filter.go (the same decode historical
+// eth_getLogs queries use).
+func decodeTxEvent(evt interface{}) (common.Hash, []*types.Log, bool) {
+	data, ok := evt.(tmtypes.EventDataTx)
+	if !ok {
+		return common.Hash{}, nil, false
+	}
+
+	logs, _ := decodeTxLogs(&data.Result)
+	return common.BytesToHash(data.Tx.Hash()), logs, true
+}
+
+// eventLoop fans block/log/pending-tx events out to every installed
+// subscriber whose filter criteria match.
+func (es *EventSystem) eventLoop() {
+	es.subscribeTendermint()
+
+	subs := make(map[*eventSub]bool)
+	for {
+		select {
+		case sub := <-es.install:
+			subs[sub] = true
+		case sub := <-es.uninstall:
+			delete(subs, sub)
+
+		case header := <-es.headers:
+			for sub := range subs {
+				if sub.typ != blockFilterTy || sub.headers == nil {
+					continue
+				}
+				select {
+				case sub.headers <- header:
+				default:
+				}
+			}
+
+		case logs := <-es.logs:
+			for sub := range subs {
+				if sub.typ != logsFilterTy || sub.logs == nil {
+					continue
+				}
+				matched := filterLogs(logs, sub.crit.Addresses, sub.crit.Topics)
+				if len(matched) == 0 {
+					continue
+				}
+				select {
+				case sub.logs <- matched:
+				default:
+				}
+			}
+
+		case hash := <-es.txs:
+			for sub := range subs {
+				if sub.typ != pendingTxFilterTy || sub.hashes == nil {
+					continue
+				}
+				select {
+				case sub.hashes <- hash:
+				default:
+				}
+			}
+		}
+	}
+}
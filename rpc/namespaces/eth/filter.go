@@ -0,0 +1,457 @@
+package eth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	abci "github.com/tendermint/abci/types"
+
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+)
+
+// filterTTL is how long an idle filter (one that hasn't been polled via
+// eth_getFilterChanges) is kept alive before it is evicted.
+const filterTTL = 5 * time.Minute
+
+// filterType identifies what kind of updates a filter accumulates.
+type filterType byte
+
+const (
+	logsFilterTy filterType = iota
+	blockFilterTy
+	pendingTxFilterTy
+)
+
+// FilterCriteria mirrors go-ethereum's eth_newFilter arguments.
+type FilterCriteria struct {
+	FromBlock *hexutil.Big     `json:"fromBlock"`
+	ToBlock   *hexutil.Big     `json:"toBlock"`
+	Addresses []common.Address `json:"addresses"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+// filterID identifies a live filter or subscription.
+type filterID string
+
+func newFilterID() filterID {
+	var b [16]byte
+	rand.Read(b[:])
+	return filterID(hex.EncodeToString(b[:]))
+}
+
+// filter is a live eth_newFilter/eth_newBlockFilter/eth_newPendingTransactionFilter
+// registration, buffering results since it was last drained.
+type filter struct {
+	typ  filterType
+	crit FilterCriteria
+
+	logs   []*types.Log
+	hashes []common.Hash
+
+	deadline time.Time
+
+	// done stops the goroutine feeding logs/hashes once the filter is
+	// uninstalled or evicted.
+	done chan struct{}
+}
+
+// PublicFilterAPI implements the standard eth_newFilter family of RPC
+// methods on top of Tendermint's event bus. Since Travis doesn't have its
+// own long-lived mempool/chain event feed, everything is bridged through
+// the local Tendermint client's NewBlock/Tx subscriptions plus a scan of
+// historical blocks' DeliverTx results for eth_getLogs.
+type PublicFilterAPI struct {
+	backend *ethereum.Backend
+	events  *EventSystem
+
+	mu      sync.Mutex
+	filters map[filterID]*filter
+}
+
+// NewPublicFilterAPI creates a new filter API instance and starts its idle
+// filter eviction loop.
+func NewPublicFilterAPI(backend *ethereum.Backend) *PublicFilterAPI {
+	api := &PublicFilterAPI{
+		backend: backend,
+		events:  NewEventSystem(backend),
+		filters: make(map[filterID]*filter),
+	}
+	go api.evictLoop()
+	return api
+}
+
+func (api *PublicFilterAPI) evictLoop() {
+	ticker := time.NewTicker(filterTTL)
+	defer ticker.Stop()
+	for range ticker.C {
+		now := time.Now()
+		api.mu.Lock()
+		for id, f := range api.filters {
+			if now.After(f.deadline) {
+				delete(api.filters, id)
+				close(f.done)
+			}
+		}
+		api.mu.Unlock()
+	}
+}
+
+func (api *PublicFilterAPI) addFilter(f *filter) filterID {
+	f.deadline = time.Now().Add(filterTTL)
+	f.done = make(chan struct{})
+	id := newFilterID()
+
+	api.mu.Lock()
+	api.filters[id] = f
+	api.mu.Unlock()
+
+	go api.pumpFilter(f)
+	return id
+}
+
+// pumpFilter installs an eventSub matching f's type/criteria and appends
+// whatever it receives into f's buffer, so GetFilterChanges/GetFilterLogs
+// have something to return between polls, until f is uninstalled or
+// evicted.
+func (api *PublicFilterAPI) pumpFilter(f *filter) {
+	sub := &eventSub{
+		typ:     f.typ,
+		crit:    f.crit,
+		logs:    make(chan []*types.Log, 32),
+		headers: make(chan *types.Header, 32),
+		hashes:  make(chan common.Hash, 32),
+	}
+	api.events.install <- sub
+	defer func() { api.events.uninstall <- sub }()
+
+	for {
+		select {
+		case logs := <-sub.logs:
+			api.mu.Lock()
+			f.logs = append(f.logs, logs...)
+			api.mu.Unlock()
+		case header := <-sub.headers:
+			api.mu.Lock()
+			f.hashes = append(f.hashes, header.Hash())
+			api.mu.Unlock()
+		case hash := <-sub.hashes:
+			api.mu.Lock()
+			f.hashes = append(f.hashes, hash)
+			api.mu.Unlock()
+		case <-f.done:
+			return
+		}
+	}
+}
+
+// NewFilter creates a new log filter, returning its id.
+func (api *PublicFilterAPI) NewFilter(crit FilterCriteria) (filterID, error) {
+	return api.addFilter(&filter{typ: logsFilterTy, crit: crit}), nil
+}
+
+// NewBlockFilter creates a filter that notifies when a new block arrives.
+func (api *PublicFilterAPI) NewBlockFilter() filterID {
+	return api.addFilter(&filter{typ: blockFilterTy})
+}
+
+// NewPendingTransactionFilter creates a filter that collects the hash of
+// each transaction as Tendermint reports it in a Tx event, i.e. once it is
+// executed in a committed block; mempool entry itself is not observed.
+func (api *PublicFilterAPI) NewPendingTransactionFilter() filterID {
+	return api.addFilter(&filter{typ: pendingTxFilterTy})
+}
+
+// UninstallFilter removes a filter. It returns true if the filter existed.
+func (api *PublicFilterAPI) UninstallFilter(id filterID) bool {
+	api.mu.Lock()
+	f, found := api.filters[id]
+	delete(api.filters, id)
+	api.mu.Unlock()
+	if found {
+		close(f.done)
+	}
+	return found
+}
+
+// GetFilterChanges drains and returns whatever the filter has accumulated
+// since it was last polled, resetting its TTL.
+func (api *PublicFilterAPI) GetFilterChanges(id filterID) (interface{}, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	f, found := api.filters[id]
+	if !found {
+		return nil, errors.New("filter not found")
+	}
+	f.deadline = time.Now().Add(filterTTL)
+
+	switch f.typ {
+	case blockFilterTy, pendingTxFilterTy:
+		hashes := f.hashes
+		f.hashes = nil
+		return hashes, nil
+	default:
+		logs := f.logs
+		f.logs = nil
+		return logs, nil
+	}
+}
+
+// GetFilterLogs returns all logs in committed blocks matching a log
+// filter's criteria, found by GetLogs; it neither reads nor resets the
+// filter's accumulated buffer.
+func (api *PublicFilterAPI) GetFilterLogs(id filterID) ([]*types.Log, error) {
+	api.mu.Lock()
+	f, found := api.filters[id]
+	api.mu.Unlock()
+	if !found {
+		return nil, errors.New("filter not found")
+	}
+	if f.typ != logsFilterTy {
+		return nil, errors.New("filter is not a logs filter")
+	}
+	return api.GetLogs(f.crit)
+}
+
+// GetLogs scans committed blocks for logs matching crit. It decodes the
+// DeliverTx results of every block in the range and matches each log
+// exactly; no Bloom prefilter is applied, so no block is skipped.
+func (api *PublicFilterAPI) GetLogs(crit FilterCriteria) ([]*types.Log, error) {
+	from, to, err := blockRange(crit)
+	if err != nil {
+		return nil, err
+	}
+
+	heights, err := api.blockHeights(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*types.Log
+	for _, height := range heights {
+		blockLogs, err := api.logsForBlock(height)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, filterLogs(blockLogs, crit.Addresses, crit.Topics)...)
+	}
+	return logs, nil
+}
+
+// blockHeights returns every block height in [from, to], paging through
+// BlockchainInfo since a single call only returns ~20 BlockMetas ending at
+// its given maxHeight -- a raw single call silently drops the rest of any
+// wider range.
+func (api *PublicFilterAPI) blockHeights(from, to int64) ([]int64, error) {
+	var heights []int64
+	cursor := to
+	for cursor >= from {
+		info, err := api.backend.LocalClient().BlockchainInfo(from, cursor)
+		if err != nil {
+			return nil, err
+		}
+		if len(info.BlockMetas) == 0 {
+			break
+		}
+		lowest := cursor
+		for _, meta := range info.BlockMetas {
+			heights = append(heights, meta.Header.Height)
+			if meta.Header.Height < lowest {
+				lowest = meta.Header.Height
+			}
+		}
+		if lowest <= from {
+			break
+		}
+		cursor = lowest - 1
+	}
+	return heights, nil
+}
+
+// Logs creates a subscription that fires for every EVM log matching crit.
+func (api *PublicFilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	sub := &eventSub{typ: logsFilterTy, crit: crit, logs: make(chan []*types.Log, 32)}
+	api.events.install <- sub
+
+	go func() {
+		defer func() { api.events.uninstall <- sub }()
+		for {
+			select {
+			case logs := <-sub.logs:
+				for _, log := range logs {
+					notifier.Notify(rpcSub.ID, log)
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// NewHeads creates a subscription that fires for every newly committed
+// block header.
+func (api *PublicFilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	sub := &eventSub{typ: blockFilterTy, headers: make(chan *types.Header, 32)}
+	api.events.install <- sub
+
+	go func() {
+		defer func() { api.events.uninstall <- sub }()
+		for {
+			select {
+			case header := <-sub.headers:
+				notifier.Notify(rpcSub.ID, header)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// NewPendingTransactions creates a subscription that fires with the hash of
+// every transaction as Tendermint reports it in a Tx event, i.e. once it is
+// executed in a committed block; mempool entry itself is not observed.
+func (api *PublicFilterAPI) NewPendingTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	sub := &eventSub{typ: pendingTxFilterTy, hashes: make(chan common.Hash, 32)}
+	api.events.install <- sub
+
+	go func() {
+		defer func() { api.events.uninstall <- sub }()
+		for {
+			select {
+			case hash := <-sub.hashes:
+				notifier.Notify(rpcSub.ID, hash)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+// logsForBlock decodes the EVM logs committed at height by reading every
+// tx's DeliverTx result for the block and pulling out the log list the vm
+// module RLP-encodes into Data.
+func (api *PublicFilterAPI) logsForBlock(height int64) ([]*types.Log, error) {
+	h := height
+	results, err := api.backend.LocalClient().BlockResults(&h)
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []*types.Log
+	for _, txResult := range results.Results.DeliverTx {
+		txLogs, err := decodeTxLogs(txResult)
+		if err != nil {
+			continue
+		}
+		logs = append(logs, txLogs...)
+	}
+	return logs, nil
+}
+
+// decodeTxLogs extracts the EVM logs the vm module RLP-encodes into a
+// DeliverTx's Data field for every tx that touches the EVM. A tx with no
+// EVM side effects (a plain stake tx, say) has an empty Data and decodes
+// to no logs rather than an error.
+func decodeTxLogs(deliverTx *abci.ResponseDeliverTx) ([]*types.Log, error) {
+	if deliverTx == nil || len(deliverTx.Data) == 0 {
+		return nil, nil
+	}
+	var logs []*types.Log
+	if err := rlp.DecodeBytes(deliverTx.Data, &logs); err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+func blockRange(crit FilterCriteria) (from, to int64, err error) {
+	if crit.FromBlock != nil {
+		from = crit.FromBlock.ToInt().Int64()
+	}
+	if crit.ToBlock != nil {
+		to = crit.ToBlock.ToInt().Int64()
+	}
+	return from, to, nil
+}
+
+// filterLogs applies the exact address/topic match against a decoded log
+// set.
+func filterLogs(logs []*types.Log, addresses []common.Address, topics [][]common.Hash) []*types.Log {
+	var out []*types.Log
+	for _, log := range logs {
+		if len(addresses) > 0 && !containsAddress(addresses, log.Address) {
+			continue
+		}
+		if !matchesTopics(topics, log.Topics) {
+			continue
+		}
+		out = append(out, log)
+	}
+	return out
+}
+
+func containsAddress(addresses []common.Address, addr common.Address) bool {
+	for _, a := range addresses {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesTopics(want [][]common.Hash, got []common.Hash) bool {
+	if len(want) > len(got) {
+		return false
+	}
+	for i, set := range want {
+		if len(set) == 0 {
+			continue
+		}
+		var found bool
+		for _, topic := range set {
+			if got[i] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
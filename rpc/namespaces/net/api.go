@@ -0,0 +1,51 @@
+package net
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// PublicNetAPI mirrors the implementation of `internal/ethapi`, since we
+// don't have access to it here.
+// #unstable
+type PublicNetAPI struct {
+	networkVersion uint64
+}
+
+// NewPublicNetAPI creates a new net API instance.
+// #unstable
+func NewPublicNetAPI(networkVersion uint64) *PublicNetAPI {
+	return &PublicNetAPI{networkVersion}
+}
+
+// Listening returns an indication if the node is listening for network connections.
+// #unstable
+func (s *PublicNetAPI) Listening() bool {
+	return true // always listening
+}
+
+// PeerCount always reports 0 connected peers.
+// #unstable
+func (s *PublicNetAPI) PeerCount() hexutil.Uint {
+	return hexutil.Uint(0)
+}
+
+// Version returns the current ethereum protocol version.
+// #unstable
+func (s *PublicNetAPI) Version() string {
+	return fmt.Sprintf("%d", s.networkVersion)
+}
+
+// APIs returns the RPC descriptors for the net namespace.
+func APIs(networkVersion uint64) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "net",
+			Version:   "1.0",
+			Service:   NewPublicNetAPI(networkVersion),
+			Public:    true,
+		},
+	}
+}
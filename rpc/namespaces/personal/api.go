@@ -0,0 +1,65 @@
+package personal
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+)
+
+// PublicPersonalAPI offers account management RPC methods.
+// #unstable
+type PublicPersonalAPI struct {
+	am *accounts.Manager
+}
+
+// NewPublicPersonalAPI creates a new personal API instance.
+// #unstable
+func NewPublicPersonalAPI(backend *ethereum.Backend) *PublicPersonalAPI {
+	return &PublicPersonalAPI{
+		am: backend.AccountManager(),
+	}
+}
+
+// UnlockAccount will unlock the account associated with the given address
+// with the given password for duration seconds. If duration is nil it will
+// use a default of 300 seconds. It returns an indication if the account was
+// unlocked.
+// copied from ethapi/api.go
+// #unstable
+func (s *PublicPersonalAPI) UnlockAccount(addr common.Address, password string, duration *uint64) (bool, error) {
+	const max = uint64(time.Duration(math.MaxInt64) / time.Second)
+	var d time.Duration
+	if duration == nil {
+		d = 300 * time.Second
+	} else if *duration > max {
+		return false, errors.New("unlock duration too large")
+	} else {
+		d = time.Duration(*duration) * time.Second
+	}
+	err := fetchKeystore(s.am).TimedUnlock(accounts.Account{Address: addr}, password, d)
+	return err == nil, err
+}
+
+// fetchKeystore retrives the encrypted keystore from the account manager.
+func fetchKeystore(am *accounts.Manager) *keystore.KeyStore {
+	return am.Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
+}
+
+// APIs returns the RPC descriptors for the personal namespace.
+func APIs(backend *ethereum.Backend) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "personal",
+			Version:   "1.0",
+			Service:   NewPublicPersonalAPI(backend),
+			Public:    false,
+		},
+	}
+}
@@ -0,0 +1,454 @@
+package stake
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/spf13/cast"
+
+	"github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/commands"
+	"github.com/cosmos/cosmos-sdk/modules/base"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-crypto"
+	"github.com/tendermint/go-wire"
+	"github.com/tendermint/go-wire/data"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/CyberMiles/travis/modules/auth"
+	"github.com/CyberMiles/travis/modules/coin"
+	"github.com/CyberMiles/travis/modules/keys"
+	"github.com/CyberMiles/travis/modules/nonce"
+	"github.com/CyberMiles/travis/modules/stake"
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+	travisCommands "github.com/CyberMiles/travis/server/commands"
+	travisSigner "github.com/CyberMiles/travis/signer"
+)
+
+// PublicStakeAPI offers stake related RPC methods.
+// #unstable
+type PublicStakeAPI struct {
+	backend   *ethereum.Backend
+	am        *accounts.Manager
+	signer    travisSigner.Backend
+	sequences *SequenceManager
+}
+
+// NewPublicStakeAPI creates a new stake API instance. The signer backend
+// defaults to the node's --signer flag (keystore if unset); each request
+// may still override it via its own Signer field.
+// #unstable
+func NewPublicStakeAPI(backend *ethereum.Backend) *PublicStakeAPI {
+	am := backend.AccountManager()
+	defaultSigner, err := travisSigner.New(viper.GetString(travisCommands.FlagSigner), am)
+	if err != nil {
+		defaultSigner = travisSigner.NewKeystoreBackend(am)
+	}
+	return &PublicStakeAPI{
+		backend:   backend,
+		am:        am,
+		signer:    defaultSigner,
+		sequences: NewSequenceManager(backend),
+	}
+}
+
+// GetPendingSequence returns the next sequence for address, accounting for
+// both committed state and the local mempool, and reserves it like any
+// other SequenceManager.Next call: a later call, or a stake tx sent without
+// an explicit sequence, gets the one after it.
+func (s *PublicStakeAPI) GetPendingSequence(address string) (uint32, error) {
+	return s.sequences.Next([]sdk.Actor{getSignerAct(address)})
+}
+
+// ResetSequence drops address's in-memory sequence reservation, for clients
+// that hit an "invalid nonce" error after a tx they expected to commit did
+// not.
+func (s *PublicStakeAPI) ResetSequence(address string) {
+	s.sequences.Reset([]sdk.Actor{getSignerAct(address)})
+}
+
+// resolveSigner returns the signer backend a request should sign with: its
+// own Signer override if set, otherwise the node's default.
+func (s *PublicStakeAPI) resolveSigner(spec string) (travisSigner.Backend, error) {
+	if spec == "" {
+		return s.signer, nil
+	}
+	return travisSigner.New(spec, s.am)
+}
+
+func (s *PublicStakeAPI) getChainID() (string, error) {
+	if s.backend.ChainID() == "" {
+		return "", errors.New("Empty chain id. Please wait for tendermint to finish starting up. ")
+	}
+
+	return s.backend.ChainID(), nil
+}
+
+type DeclareCandidacyArgs struct {
+	Sequence uint32 `json:"sequence"`
+	From     string `json:"from"`
+	PubKey   string `json:"pubKey"`
+	Signer   string `json:"signer,omitempty"`
+}
+
+func (s *PublicStakeAPI) DeclareCandidacy(args DeclareCandidacyArgs) (*ethereum.StakeTxResult, error) {
+	tx, err := s.prepareDeclareCandidacyTx(args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.broadcastTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return ethereum.DecodeStakeResult(result, ethereum.StakeTxResult{
+		Type:      ethereum.StakeEventDeclare,
+		Candidate: args.From,
+		PubKey:    args.PubKey,
+	})
+}
+
+func (s *PublicStakeAPI) prepareDeclareCandidacyTx(args DeclareCandidacyArgs) (sdk.Tx, error) {
+	pubKey, err := stake.GetPubKey(args.PubKey)
+	if err != nil {
+		return sdk.Tx{}, err
+	}
+	tx := stake.NewTxDeclare(pubKey)
+	return s.wrapAndSignTx(tx, args.From, args.Sequence, args.Signer)
+}
+
+type ProposeSlotArgs struct {
+	Sequence    uint32 `json:"sequence"`
+	From        string `json:"from"`
+	PubKey      string `json:"pubKey"`
+	Amount      int64  `json:"amount"`
+	ProposedRoi int64  `json:"proposedRoi"`
+	Signer      string `json:"signer,omitempty"`
+}
+
+func (s *PublicStakeAPI) ProposeSlot(args ProposeSlotArgs) (*ethereum.StakeTxResult, error) {
+	tx, err := s.prepareProposeSlotTx(args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.broadcastTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return ethereum.DecodeStakeResult(result, ethereum.StakeTxResult{
+		Type:      ethereum.StakeEventProposeSlot,
+		Candidate: args.From,
+		PubKey:    args.PubKey,
+		Amount:    args.Amount,
+	})
+}
+
+func (s *PublicStakeAPI) prepareProposeSlotTx(args ProposeSlotArgs) (sdk.Tx, error) {
+	pubKey, err := stake.GetPubKey(args.PubKey)
+	if err != nil {
+		return sdk.Tx{}, err
+	}
+	tx := stake.NewTxProposeSlot(pubKey, args.Amount, args.ProposedRoi)
+	return s.wrapAndSignTx(tx, args.From, args.Sequence, args.Signer)
+}
+
+type AcceptSlotArgs struct {
+	Sequence uint32 `json:"sequence"`
+	From     string `json:"from"`
+	Amount   int64  `json:"amount"`
+	SlotId   string `json:"slotId"`
+	Signer   string `json:"signer,omitempty"`
+}
+
+func (s *PublicStakeAPI) AcceptSlot(args AcceptSlotArgs) (*ethereum.StakeTxResult, error) {
+	tx, err := s.prepareAcceptSlotTx(args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.broadcastTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return ethereum.DecodeStakeResult(result, ethereum.StakeTxResult{
+		Type:   ethereum.StakeEventAcceptSlot,
+		Amount: args.Amount,
+		SlotId: args.SlotId,
+	})
+}
+
+func (s *PublicStakeAPI) prepareAcceptSlotTx(args AcceptSlotArgs) (sdk.Tx, error) {
+	tx := stake.NewTxAcceptSlot(args.Amount, args.SlotId)
+	return s.wrapAndSignTx(tx, args.From, args.Sequence, args.Signer)
+}
+
+type WithdrawSlotArgs struct {
+	Sequence uint32 `json:"sequence"`
+	From     string `json:"from"`
+	Amount   int64  `json:"amount"`
+	SlotId   string `json:"slotId"`
+	Signer   string `json:"signer,omitempty"`
+}
+
+func (s *PublicStakeAPI) WithdrawSlot(args WithdrawSlotArgs) (*ethereum.StakeTxResult, error) {
+	tx, err := s.prepareWithdrawSlotTx(args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.broadcastTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return ethereum.DecodeStakeResult(result, ethereum.StakeTxResult{
+		Type:   ethereum.StakeEventWithdrawSlot,
+		Amount: args.Amount,
+		SlotId: args.SlotId,
+	})
+}
+
+func (s *PublicStakeAPI) prepareWithdrawSlotTx(args WithdrawSlotArgs) (sdk.Tx, error) {
+	tx := stake.NewTxWithdrawSlot(args.Amount, args.SlotId)
+	return s.wrapAndSignTx(tx, args.From, args.Sequence, args.Signer)
+}
+
+type CancelSlotArgs struct {
+	Sequence uint32 `json:"sequence"`
+	From     string `json:"from"`
+	PubKey   string `json:"pubKey"`
+	SlotId   string `json:"slotId"`
+	Signer   string `json:"signer,omitempty"`
+}
+
+func (s *PublicStakeAPI) CancelSlot(args CancelSlotArgs) (*ethereum.StakeTxResult, error) {
+	tx, err := s.prepareCancelSlotTx(args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.broadcastTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return ethereum.DecodeStakeResult(result, ethereum.StakeTxResult{
+		Type:      ethereum.StakeEventCancelSlot,
+		Candidate: args.From,
+		PubKey:    args.PubKey,
+		SlotId:    args.SlotId,
+	})
+}
+
+func (s *PublicStakeAPI) prepareCancelSlotTx(args CancelSlotArgs) (sdk.Tx, error) {
+	pubKey, err := stake.GetPubKey(args.PubKey)
+	if err != nil {
+		return sdk.Tx{}, err
+	}
+	tx := stake.NewTxCancelSlot(pubKey, args.SlotId)
+	return s.wrapAndSignTx(tx, args.From, args.Sequence, args.Signer)
+}
+
+func (s *PublicStakeAPI) wrapAndSignTx(tx sdk.Tx, address string, sequence uint32, signerSpec string) (sdk.Tx, error) {
+	// wrap
+	// only add the actual signer to the nonce
+	signers := []sdk.Actor{getSignerAct(address)}
+	if sequence <= 0 {
+		// calculate default sequence, mempool-aware to avoid colliding with
+		// a tx from the same signer that hasn't committed yet
+		seq, err := s.sequences.Next(signers)
+		if err != nil {
+			return sdk.Tx{}, err
+		}
+		sequence = seq
+	}
+	tx = nonce.NewTx(sequence, signers, tx)
+
+	chainID, err := s.getChainID()
+	if err != nil {
+		return sdk.Tx{}, err
+	}
+	tx = base.NewChainTx(chainID, 0, tx)
+	tx = auth.NewSig(tx).Wrap()
+
+	// sign
+	err = s.signTx(tx, address, signerSpec)
+	if err != nil {
+		return sdk.Tx{}, err
+	}
+	return tx, err
+}
+
+// sign the transaction with private key
+func (s *PublicStakeAPI) signTx(tx sdk.Tx, address string, signerSpec string) error {
+	// validate tx client-side
+	err := tx.ValidateBasic()
+	if err != nil {
+		return err
+	}
+
+	if sign, ok := tx.Unwrap().(keys.Signable); ok {
+		if address == "" {
+			return errors.New("address is required to sign tx")
+		}
+		err := s.sign(sign, address, signerSpec)
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+func (s *PublicStakeAPI) sign(data keys.Signable, address string, signerSpec string) error {
+	ethTx := types.NewTransaction(
+		0,
+		common.Address([20]byte{}),
+		big.NewInt(0),
+		big.NewInt(0),
+		big.NewInt(0),
+		data.SignBytes(),
+	)
+
+	signBytes, err := rlp.EncodeToBytes(ethTx)
+	if err != nil {
+		return err
+	}
+
+	backend, err := s.resolveSigner(signerSpec)
+	if err != nil {
+		return err
+	}
+
+	addr := common.HexToAddress(address)
+	signedBytes, err := backend.SignStakeTx(addr, signBytes, s.backend.EthChainID())
+	if err != nil {
+		return err
+	}
+
+	var signed types.Transaction
+	if err := rlp.DecodeBytes(signedBytes, &signed); err != nil {
+		return err
+	}
+
+	return data.Sign(&signed)
+}
+
+func (s *PublicStakeAPI) broadcastTx(tx sdk.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	key := wire.BinaryBytes(tx)
+	return s.backend.LocalClient().BroadcastTxCommit(key)
+}
+
+func getSignerAct(address string) (res sdk.Actor) {
+	// this could be much cooler with multisig...
+	signer := common.HexToAddress(address)
+	res = auth.SigPerm(signer.Bytes())
+	return res
+}
+
+type StakeQueryResult struct {
+	Height int64       `json:"height"`
+	Data   interface{} `json:"data"`
+}
+
+func (s *PublicStakeAPI) QueryValidators(height uint64) (*StakeQueryResult, error) {
+	var pks []crypto.PubKey
+	key := stack.PrefixedKey(stake.Name(), stake.CandidatesPubKeysKey)
+	h, err := s.getParsed("/key", key, &pks, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, pks}, nil
+}
+
+func (s *PublicStakeAPI) QueryValidator(pubkey string, height uint64) (*StakeQueryResult, error) {
+	pk, err := stake.GetPubKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidate stake.Candidate
+	key := stack.PrefixedKey(stake.Name(), stake.GetCandidateKey(pk))
+	h, err := s.getParsed("/key", key, &candidate, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, candidate}, nil
+}
+
+func (s *PublicStakeAPI) QuerySlots(address string, height uint64) (*StakeQueryResult, error) {
+	delegator, err := commands.ParseActor(address)
+	if err != nil {
+		return nil, err
+	}
+	delegator = coin.ChainAddr(delegator)
+
+	var candidates []crypto.PubKey
+	key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondsKey(delegator))
+	h, err := s.getParsed("/key", key, &candidates, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, candidates}, nil
+}
+
+func (s *PublicStakeAPI) QuerySlot(slotId string, height uint64) (*StakeQueryResult, error) {
+	var slot stake.Slot
+	h, err := s.getParsed("/slot", []byte(slotId), &slot, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, slot}, nil
+}
+
+func (s *PublicStakeAPI) QueryDelegator(address string, height uint64) (*StakeQueryResult, error) {
+	var slotDelegates []*stake.SlotDelegate
+	h, err := s.getParsed("/delegator", []byte(address), &slotDelegates, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StakeQueryResult{h, slotDelegates}, nil
+}
+
+func (s *PublicStakeAPI) getParsed(path string, key []byte, data interface{}, height uint64) (int64, error) {
+	bs, h, err := s.get(path, key, cast.ToInt64(height))
+	if err != nil {
+		return 0, err
+	}
+	if len(bs) == 0 {
+		return h, client.ErrNoData()
+	}
+	err = wire.ReadBinaryBytes(bs, data)
+	if err != nil {
+		return 0, err
+	}
+	return h, nil
+}
+
+func (s *PublicStakeAPI) get(path string, key []byte, height int64) (data.Bytes, int64, error) {
+	node := s.backend.LocalClient()
+	resp, err := node.ABCIQueryWithOptions(path, key,
+		rpcclient.ABCIQueryOptions{Trusted: true, Height: int64(height)})
+	if resp == nil {
+		return nil, height, err
+	}
+	return data.Bytes(resp.Response.Value), resp.Response.Height, err
+}
+
+// APIs returns the RPC descriptors for the stake namespace.
+func APIs(backend *ethereum.Backend) []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "stake",
+			Version:   "1.0",
+			Service:   NewPublicStakeAPI(backend),
+			Public:    true,
+		},
+	}
+}
@@ -0,0 +1,163 @@
+package stake
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/tendermint/go-wire"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/CyberMiles/travis/modules/nonce"
+	"github.com/CyberMiles/travis/modules/vm/ethereum"
+)
+
+// reservationTTL bounds how long an in-memory sequence reservation is
+// honored before it's considered stale and dropped.
+const reservationTTL = 30 * time.Second
+
+// unwrapDepth bounds how many middleware layers (auth.Sig, base.Chain, ...)
+// decodeNonceTx peels off a raw broadcast tx before giving up on finding a
+// nonce.Tx, so a malformed or unexpectedly-deep tx can't spin forever.
+const unwrapDepth = 8
+
+type reservation struct {
+	sequence uint32
+	expires  time.Time
+}
+
+// sequenceClient is the subset of the Tendermint RPC client SequenceManager
+// needs, pulled out as an interface (mirroring eventsClient in the eth
+// namespace) so it can be exercised with a fake in tests.
+type sequenceClient interface {
+	ABCIQuery(path string, data []byte) (*ctypes.ResultABCIQuery, error)
+	UnconfirmedTxs() (*ctypes.ResultUnconfirmedTxs, error)
+}
+
+// SequenceManager computes the next usable nonce-module sequence for a
+// signer by combining the committed ABCI state, the local mempool's
+// in-flight tx count, and a short-lived in-memory reservation -- so two RPC
+// calls from the same signer submitted back-to-back don't both compute
+// committed+1 and collide.
+type SequenceManager struct {
+	client sequenceClient
+
+	mu           sync.Mutex
+	reservations map[string]reservation
+}
+
+// NewSequenceManager creates a SequenceManager backed by backend's local
+// Tendermint client.
+func NewSequenceManager(backend *ethereum.Backend) *SequenceManager {
+	return newSequenceManager(backend.LocalClient())
+}
+
+func newSequenceManager(client sequenceClient) *SequenceManager {
+	return &SequenceManager{
+		client:       client,
+		reservations: make(map[string]reservation),
+	}
+}
+
+// Next returns the sequence signers should use for their next tx, and
+// reserves it for reservationTTL.
+func (m *SequenceManager) Next(signers []sdk.Actor) (uint32, error) {
+	committed, err := m.committed(signers)
+	if err != nil {
+		return 0, err
+	}
+	pending, err := m.pendingCount(signers)
+	if err != nil {
+		return 0, err
+	}
+	base := committed + pending
+
+	key := actorKey(signers)
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if res, ok := m.reservations[key]; ok && now.Before(res.expires) && res.sequence > base {
+		base = res.sequence
+	}
+	next := base + 1
+	m.reservations[key] = reservation{sequence: next, expires: now.Add(reservationTTL)}
+	return next, nil
+}
+
+// Reset drops any in-memory reservation for signers, for clients that hit
+// an "invalid nonce" error after a reservation outlived a rejected tx.
+func (m *SequenceManager) Reset(signers []sdk.Actor) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.reservations, actorKey(signers))
+}
+
+func (m *SequenceManager) committed(signers []sdk.Actor) (uint32, error) {
+	key := stack.PrefixedKey(nonce.NameNonce, nonce.GetSeqKey(signers))
+	result, err := m.client.ABCIQuery("/key", key)
+	if err != nil {
+		return 0, err
+	}
+	if len(result.Response.Value) == 0 {
+		return 0, nil
+	}
+
+	var sequence uint32
+	if err := wire.ReadBinaryBytes(result.Response.Value, &sequence); err != nil {
+		return 0, err
+	}
+	return sequence, nil
+}
+
+// pendingCount counts unconfirmed mempool transactions signed by signers,
+// so a second call before the first commits still moves the sequence
+// forward.
+func (m *SequenceManager) pendingCount(signers []sdk.Actor) (uint32, error) {
+	result, err := m.client.UnconfirmedTxs()
+	if err != nil {
+		return 0, err
+	}
+
+	key := actorKey(signers)
+	var count uint32
+	for _, raw := range result.Txs {
+		ntx, ok := decodeNonceTx([]byte(raw))
+		if ok && actorKey(ntx.Signers) == key {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// decodeNonceTx decodes a raw mempool tx down to the nonce-module layer, so
+// its signers can be compared without caring about the inner stake tx it
+// wraps. wrapAndSignTx (see api.go) nests nonce.NewTx inside
+// base.NewChainTx inside auth.NewSig(...).Wrap(), so the broadcast bytes
+// are an auth/chain wrapper around the nonce tx, not a bare nonce.Tx --
+// peel those layers off via sdk.Tx.Unwrap() until a nonce.Tx falls out.
+func decodeNonceTx(raw []byte) (nonce.Tx, bool) {
+	var tx sdk.Tx
+	if err := wire.ReadBinaryBytes(raw, &tx); err != nil {
+		return nonce.Tx{}, false
+	}
+
+	for i := 0; i < unwrapDepth; i++ {
+		if ntx, ok := tx.(nonce.Tx); ok {
+			return ntx, true
+		}
+		inner := tx.Unwrap()
+		if inner == nil {
+			return nonce.Tx{}, false
+		}
+		tx = inner
+	}
+	return nonce.Tx{}, false
+}
+
+// actorKey builds a stable map key for a signer set.
+func actorKey(signers []sdk.Actor) string {
+	return hex.EncodeToString(wire.BinaryBytes(signers))
+}
@@ -0,0 +1,44 @@
+package web3
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// clientIdentifier is reported by the web3_clientVersion RPC method.
+const clientIdentifier = "travis"
+
+// PublicWeb3API offers helper utils under the web3 namespace.
+// #unstable
+type PublicWeb3API struct{}
+
+// NewPublicWeb3API creates a new web3 API instance.
+// #unstable
+func NewPublicWeb3API() *PublicWeb3API {
+	return &PublicWeb3API{}
+}
+
+// ClientVersion returns the client version string.
+// #unstable
+func (s *PublicWeb3API) ClientVersion() string {
+	return clientIdentifier
+}
+
+// Sha3 returns the keccak-256 hash of the given data.
+// #unstable
+func (s *PublicWeb3API) Sha3(input hexutil.Bytes) hexutil.Bytes {
+	return crypto.Keccak256(input)
+}
+
+// APIs returns the RPC descriptors for the web3 namespace.
+func APIs() []rpc.API {
+	return []rpc.API{
+		{
+			Namespace: "web3",
+			Version:   "1.0",
+			Service:   NewPublicWeb3API(),
+			Public:    true,
+		},
+	}
+}
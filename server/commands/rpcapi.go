@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// FlagRPCApi selects the RPC namespaces the node serves, as a comma
+// separated list (e.g. "eth,net,web3,personal,stake,cmt"). Registered on
+// the global flag set the node command's persistent flags are built from,
+// so viper.GetString(FlagRPCApi) reflects --rpcapi once the node starts.
+var FlagRPCApi = "rpcapi"
+
+func init() {
+	pflag.String(FlagRPCApi, "", "Comma separated list of RPC namespaces to enable (default: eth,net,web3,personal)")
+	viper.BindPFlag(FlagRPCApi, pflag.Lookup(FlagRPCApi))
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// FlagSigner selects the stake RPC namespace's transaction signer backend:
+// "keystore" (default), "hd", or "remote:<url>". Registered on the global
+// flag set the node command's persistent flags are built from, so
+// viper.GetString(FlagSigner) reflects --signer once the node starts.
+var FlagSigner = "signer"
+
+func init() {
+	pflag.String(FlagSigner, "", "Stake tx signer backend: keystore (default), hd, or remote:<url>")
+	viper.BindPFlag(FlagSigner, pflag.Lookup(FlagSigner))
+}
@@ -0,0 +1,67 @@
+package signer
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// HDBackend derives a per-request BIP32/BIP44 account from the accounts
+// manager's wallets and signs with it, rather than requiring every address
+// to already exist as an imported keystore account.
+type HDBackend struct {
+	am *accounts.Manager
+
+	mu    sync.Mutex
+	paths map[common.Address]accounts.DerivationPath
+}
+
+// NewHDBackend creates an HD-wallet-backed signer using the default
+// derivation path unless overridden per address via SetDerivationPath.
+func NewHDBackend(am *accounts.Manager) *HDBackend {
+	return &HDBackend{
+		am:    am,
+		paths: make(map[common.Address]accounts.DerivationPath),
+	}
+}
+
+// SetDerivationPath overrides the BIP32/44 derivation path used for from.
+func (b *HDBackend) SetDerivationPath(from common.Address, path accounts.DerivationPath) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.paths[from] = path
+}
+
+func (b *HDBackend) derivationPath(from common.Address) accounts.DerivationPath {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if path, ok := b.paths[from]; ok {
+		return path
+	}
+	return accounts.DefaultBaseDerivationPath
+}
+
+func (b *HDBackend) SignStakeTx(from common.Address, signBytes []byte, chainID *big.Int) ([]byte, error) {
+	var tx types.Transaction
+	if err := rlp.DecodeBytes(signBytes, &tx); err != nil {
+		return nil, err
+	}
+
+	path := b.derivationPath(from)
+	for _, wallet := range b.am.Wallets() {
+		account, err := wallet.Derive(path, true)
+		if err != nil || account.Address != from {
+			continue
+		}
+		signed, err := wallet.SignTx(account, &tx, chainID)
+		if err != nil {
+			return nil, err
+		}
+		return rlp.EncodeToBytes(signed)
+	}
+	return nil, accounts.ErrUnknownAccount
+}
@@ -0,0 +1,40 @@
+package signer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// KeystoreBackend signs with a local encrypted keystore account via the
+// shared go-ethereum account manager. This is the original signing path and
+// remains the default.
+type KeystoreBackend struct {
+	am *accounts.Manager
+}
+
+// NewKeystoreBackend creates a keystore-backed signer.
+func NewKeystoreBackend(am *accounts.Manager) *KeystoreBackend {
+	return &KeystoreBackend{am: am}
+}
+
+func (b *KeystoreBackend) SignStakeTx(from common.Address, signBytes []byte, chainID *big.Int) ([]byte, error) {
+	var tx types.Transaction
+	if err := rlp.DecodeBytes(signBytes, &tx); err != nil {
+		return nil, err
+	}
+
+	account := accounts.Account{Address: from}
+	wallet, err := b.am.Find(account)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := wallet.SignTx(account, &tx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(signed)
+}
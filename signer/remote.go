@@ -0,0 +1,115 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// RemoteBackend delegates signing to an external clef-style signer speaking
+// the `account_signTransaction` JSON-RPC method over HTTP, keeping private
+// keys out of the travis process entirely.
+type RemoteBackend struct {
+	url    string
+	client *http.Client
+}
+
+// NewRemoteBackend creates a signer that forwards requests to url.
+func NewRemoteBackend(url string) *RemoteBackend {
+	return &RemoteBackend{
+		url:    url,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// sendTxArgs mirrors clef's SendTxArgs request shape closely enough for
+// account_signTransaction to reconstruct and sign the exact same tx
+// KeystoreBackend/HDBackend's wallet.SignTx would -- unlike account_signData,
+// which signs an EIP-191-wrapped digest of arbitrary bytes and so can't
+// produce a signature that recovers to `from` when applied to the tx.
+type sendTxArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Gas      *hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Value    *hexutil.Big    `json:"value"`
+	Nonce    *hexutil.Uint64 `json:"nonce"`
+	Data     *hexutil.Bytes  `json:"data"`
+}
+
+type signTransactionRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      int           `json:"id"`
+}
+
+type signTransactionResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+type signTransactionResponse struct {
+	Result *signTransactionResult `json:"result"`
+	Error  *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (b *RemoteBackend) SignStakeTx(from common.Address, signBytes []byte, chainID *big.Int) ([]byte, error) {
+	var tx types.Transaction
+	if err := rlp.DecodeBytes(signBytes, &tx); err != nil {
+		return nil, err
+	}
+
+	gas := hexutil.Uint64(tx.Gas())
+	nonce := hexutil.Uint64(tx.Nonce())
+	gasPrice := (*hexutil.Big)(tx.GasPrice())
+	value := (*hexutil.Big)(tx.Value())
+	data := hexutil.Bytes(tx.Data())
+	to := tx.To()
+
+	req := signTransactionRequest{
+		JSONRPC: "2.0",
+		Method:  "account_signTransaction",
+		Params: []interface{}{sendTxArgs{
+			From:     from,
+			To:       to,
+			Gas:      &gas,
+			GasPrice: gasPrice,
+			Value:    value,
+			Nonce:    &nonce,
+			Data:     &data,
+		}},
+		ID: 1,
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.client.Post(b.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var rpcResp signTransactionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, err
+	}
+	if rpcResp.Error != nil {
+		return nil, errors.New(rpcResp.Error.Message)
+	}
+	if rpcResp.Result == nil || len(rpcResp.Result.Raw) == 0 {
+		return nil, errors.New("remote signer: empty signed transaction")
+	}
+	return []byte(rpcResp.Result.Raw), nil
+}
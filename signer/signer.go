@@ -0,0 +1,36 @@
+// Package signer provides pluggable transaction signing backends for the
+// stake RPC namespace, so a stake tx can be signed by a local keystore, an
+// HD wallet derivation, or a remote clef-style signer without the RPC layer
+// caring which.
+package signer
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Backend signs an RLP-encoded, unsigned go-ethereum transaction envelope
+// (signBytes) for from under chainID, returning the RLP-encoded signed
+// envelope.
+type Backend interface {
+	SignStakeTx(from common.Address, signBytes []byte, chainID *big.Int) ([]byte, error)
+}
+
+// New builds a Backend from a --signer flag value: "keystore" (the
+// default), "hd", or "remote:<url>".
+func New(spec string, am *accounts.Manager) (Backend, error) {
+	switch {
+	case spec == "" || spec == "keystore":
+		return NewKeystoreBackend(am), nil
+	case spec == "hd":
+		return NewHDBackend(am), nil
+	case strings.HasPrefix(spec, "remote:"):
+		return NewRemoteBackend(strings.TrimPrefix(spec, "remote:")), nil
+	default:
+		return nil, fmt.Errorf("unknown signer backend %q", spec)
+	}
+}